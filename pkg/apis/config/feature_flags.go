@@ -0,0 +1,64 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import "context"
+
+// DefaultMaxMatrixCombinationsSize is the default maximum number of combinations a matrix's
+// Cartesian product may generate before validation rejects it.
+const DefaultMaxMatrixCombinationsSize = 256
+
+// FeatureFlags holds the features configurable at the Tekton Pipelines controller level.
+type FeatureFlags struct {
+	// EnableAPIFields controls which (alpha/beta/stable) API fields are recognized. When set
+	// to "alpha", extra params passed to a Task are no longer rejected - see
+	// extraParamsNames in pkg/reconciler/taskrun.
+	EnableAPIFields string
+	// MaxMatrixCombinationsSize bounds the Cartesian product size a matrix may fan out to.
+	MaxMatrixCombinationsSize int
+}
+
+// Config holds the collection of configurations read from the configmaps in the
+// tekton-pipelines namespace.
+type Config struct {
+	FeatureFlags *FeatureFlags
+}
+
+// defaultConfig is returned by FromContextOrDefaults when no Config has been stored on ctx.
+func defaultConfig() *Config {
+	return &Config{
+		FeatureFlags: &FeatureFlags{
+			MaxMatrixCombinationsSize: DefaultMaxMatrixCombinationsSize,
+		},
+	}
+}
+
+type configContextKey struct{}
+
+// ToContext stores cfg on ctx for later retrieval via FromContextOrDefaults.
+func ToContext(ctx context.Context, cfg *Config) context.Context {
+	return context.WithValue(ctx, configContextKey{}, cfg)
+}
+
+// FromContextOrDefaults returns the Config stored on ctx, or a Config populated with default
+// values if none was stored.
+func FromContextOrDefaults(ctx context.Context) *Config {
+	if cfg, ok := ctx.Value(configContextKey{}).(*Config); ok && cfg != nil {
+		return cfg
+	}
+	return defaultConfig()
+}
@@ -0,0 +1,67 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+// ParamType indicates the type of an input or output Param or TaskResult.
+type ParamType string
+
+// Valid ParamTypes.
+const (
+	ParamTypeString ParamType = "string"
+	ParamTypeArray  ParamType = "array"
+	ParamTypeObject ParamType = "object"
+)
+
+// ParamSpec defines parameters that need to be supplied by a TaskRun or PipelineRun.
+type ParamSpec struct {
+	// Name declares the name by which a parameter is referenced.
+	Name string `json:"name"`
+	// Type is the user-specified type of the parameter. Defaults to string if not set.
+	Type ParamType `json:"type,omitempty"`
+	// Description is a user-facing description of the parameter.
+	Description string `json:"description,omitempty"`
+	// Properties is the JSON Schema properties to support key-value pairs for an object param.
+	Properties map[string]PropertySpec `json:"properties,omitempty"`
+	// Default is the value a param takes if no value is supplied. It may itself reference
+	// other params (e.g. "$(params.other)" or "$(params.config.region)"); see the param
+	// reference resolver in pkg/reconciler/taskrun for how those are resolved.
+	Default *ArrayOrString `json:"default,omitempty"`
+	// Validation is an optional CEL expression evaluated against the param's value (exposed
+	// to the expression as `self`) and every provided param (exposed as `params`). A value
+	// that doesn't satisfy the expression fails TaskRun admission.
+	Validation string `json:"validation,omitempty"`
+}
+
+// PropertySpec defines the struct for object keys.
+type PropertySpec struct {
+	Type ParamType `json:"type,omitempty"`
+}
+
+// Param declares a value to use for the Param called Name.
+type Param struct {
+	Name  string        `json:"name"`
+	Value ArrayOrString `json:"value"`
+}
+
+// ArrayOrString is a type that can hold a single string, array of strings or a map of
+// strings, to help with backwards compatibility for Params.
+type ArrayOrString struct {
+	Type      ParamType         `json:"type"`
+	StringVal string            `json:"stringVal,omitempty"`
+	ArrayVal  []string          `json:"arrayVal,omitempty"`
+	ObjectVal map[string]string `json:"objectVal,omitempty"`
+}
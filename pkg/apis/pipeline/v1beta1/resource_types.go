@@ -0,0 +1,38 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	resourcev1alpha1 "github.com/tektoncd/pipeline/pkg/apis/resource/v1alpha1"
+)
+
+// TaskResource defines an input or output Resource declared as a requirement by a Task. The
+// Name field will be used to refer to this Resource in the Task's Steps.
+type TaskResource struct {
+	// Name declares the name by which a resource is referenced in the Task's Steps.
+	Name string `json:"name"`
+	// Type is the type of resource required by the Task, e.g. "git", "image", "storage".
+	Type resourcev1alpha1.PipelineResourceType `json:"type"`
+	// Optional declares the resource as optional; defaults to false (required).
+	Optional bool `json:"optional,omitempty"`
+}
+
+// TaskResources allows a Task to declare its input and output resources.
+type TaskResources struct {
+	Inputs  []TaskResource `json:"inputs,omitempty"`
+	Outputs []TaskResource `json:"outputs,omitempty"`
+}
@@ -0,0 +1,40 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+// TaskResult is used to describe the results of a Task.
+type TaskResult struct {
+	// Name the given name.
+	Name string `json:"name"`
+	// Type is the user-specified type of the result. Defaults to string if not set.
+	Type ParamType `json:"type,omitempty"`
+	// Description is a human-readable description of the result.
+	Description string `json:"description,omitempty"`
+	// Properties is the JSON Schema properties to support key-value pairs for an object result.
+	Properties map[string]PropertySpec `json:"properties,omitempty"`
+	// Validation is an optional CEL expression evaluated against the emitted result value
+	// (exposed as `self`) and every result the TaskRun emitted (exposed as `results`). A
+	// value that doesn't satisfy the expression fails result emission.
+	Validation string `json:"validation,omitempty"`
+}
+
+// TaskRunResult is used to describe the results of a TaskRun.
+type TaskRunResult struct {
+	Name  string        `json:"name"`
+	Type  ParamType     `json:"type,omitempty"`
+	Value ArrayOrString `json:"value"`
+}
@@ -0,0 +1,61 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
+// TaskSpec describes the work that will be performed by a Task.
+type TaskSpec struct {
+	// Params is the list of input parameters required to run the task.
+	Params []ParamSpec `json:"params,omitempty"`
+	// Resources declares the input and output resources required by the Task.
+	Resources *TaskResources `json:"resources,omitempty"`
+	// Steps are the steps that comprise the Task.
+	Steps []Step `json:"steps,omitempty"`
+	// Sidecars are additional containers that run alongside the Task's Steps.
+	Sidecars []Sidecar `json:"sidecars,omitempty"`
+	// StepTemplate can be used as the basis for all Step containers in this Task.
+	StepTemplate *StepTemplate `json:"stepTemplate,omitempty"`
+	// Results are values that this Task can output.
+	Results []TaskResult `json:"results,omitempty"`
+	// ResultsBuildType is a URI identifying the provenance BuildType the Task's Results
+	// conform to (e.g. "https://slsa.dev/provenance/v0.2"). When set, the reconciler
+	// dispatches to the ResultSchemaValidator registered for it via RegisterResultSchema
+	// (pkg/reconciler/taskrun) to validate the emitted Results against that build type's
+	// required shape, in addition to the generic type/key checks every Task gets.
+	ResultsBuildType string `json:"resultsBuildType,omitempty"`
+}
+
+// Step embeds a container definition and adds Task-specific configuration on top of it.
+type Step struct {
+	Name      string                      `json:"name"`
+	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+}
+
+// Sidecar has nearly the same data structure as Step but does not have the ability to
+// timeout.
+type Sidecar struct {
+	Name string `json:"name"`
+}
+
+// StepTemplate is a template for a Step's container, used as the basis for every Step
+// container in a Task unless the Step overrides it.
+type StepTemplate struct {
+	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+}
@@ -0,0 +1,86 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
+// TaskRun represents a single execution of a Task.
+type TaskRun struct {
+	Spec   TaskRunSpec   `json:"spec"`
+	Status TaskRunStatus `json:"status,omitempty"`
+}
+
+// TaskRunSpec defines the desired state of a TaskRun.
+type TaskRunSpec struct {
+	// Params is the list of params to be used to run the Task.
+	Params []Param `json:"params,omitempty"`
+	// TaskSpec is an embedded, fully-formed Task spec, used instead of a TaskRef.
+	TaskSpec *TaskSpec `json:"taskSpec,omitempty"`
+	// StepOverrides overrides the container resources for individual Steps by name.
+	StepOverrides []TaskRunStepOverride `json:"stepOverrides,omitempty"`
+	// SidecarOverrides overrides the container resources for individual Sidecars by name.
+	SidecarOverrides []TaskRunSidecarOverride `json:"sidecarOverrides,omitempty"`
+}
+
+// TaskRunStepOverride is used to override the values of a Step in the corresponding Task.
+type TaskRunStepOverride struct {
+	Name string `json:"name"`
+}
+
+// TaskRunSidecarOverride is used to override the values of a Sidecar in the corresponding
+// Task.
+type TaskRunSidecarOverride struct {
+	Name string `json:"name"`
+}
+
+// TaskRunStatus defines the observed state of a TaskRun.
+type TaskRunStatus struct {
+	// TaskRunResults are the results emitted from the Task's Steps.
+	TaskRunResults []TaskRunResult `json:"taskResults,omitempty"`
+	// Conditions holds the latest available observations of the TaskRun's state.
+	Conditions []Condition `json:"conditions,omitempty"`
+}
+
+// SetCondition updates the condition with the same Type as cond, or appends cond if no such
+// condition exists yet. Callers report overall validation failures (e.g. the JSON produced by
+// EncodeValidationErrors) through the Succeeded condition's Message so that a single round-trip
+// carries every violation found.
+func (s *TaskRunStatus) SetCondition(cond Condition) {
+	for i, c := range s.Conditions {
+		if c.Type == cond.Type {
+			s.Conditions[i] = cond
+			return
+		}
+	}
+	s.Conditions = append(s.Conditions, cond)
+}
+
+// ConditionType identifies a particular condition reported on a TaskRun's status.
+type ConditionType string
+
+// ConditionSucceeded is the condition a TaskRun reports its overall pass/fail state on.
+const ConditionSucceeded ConditionType = "Succeeded"
+
+// Condition describes a single observation of a TaskRun's state at a point in time.
+type Condition struct {
+	Type    ConditionType          `json:"type"`
+	Status  corev1.ConditionStatus `json:"status"`
+	Reason  string                 `json:"reason,omitempty"`
+	Message string                 `json:"message,omitempty"`
+}
@@ -0,0 +1,40 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+// PipelineResourceType represents the type of endpoint the resource is, so that the
+// controller will know this resource should be fetched and optionally what additional
+// metadata should be provided for it.
+type PipelineResourceType string
+
+// ResourceParam declares a string value to use for the parameter called Name, and is used in
+// the specific context that this parameter is being used for a PipelineResource.
+type ResourceParam struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// PipelineResourceSpec defines an individual resource used in the pipeline.
+type PipelineResourceSpec struct {
+	Type   PipelineResourceType `json:"type"`
+	Params []ResourceParam      `json:"params"`
+}
+
+// PipelineResource describes a resource that is an input to or output from a Task.
+type PipelineResource struct {
+	Spec PipelineResourceSpec `json:"spec"`
+}
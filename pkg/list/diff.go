@@ -0,0 +1,33 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package list
+
+// DiffLeft returns the elements of left that are not present in right, preserving left's
+// order and including duplicates exactly as they appear in left.
+func DiffLeft(left, right []string) []string {
+	rightSet := make(map[string]struct{}, len(right))
+	for _, r := range right {
+		rightSet[r] = struct{}{}
+	}
+	var diff []string
+	for _, l := range left {
+		if _, ok := rightSet[l]; !ok {
+			diff = append(diff, l)
+		}
+	}
+	return diff
+}
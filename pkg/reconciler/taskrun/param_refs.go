@@ -0,0 +1,271 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package taskrun
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/go-multierror"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+)
+
+// paramRefPattern matches $(params.foo) and the object-key form $(params.foo.bar).
+var paramRefPattern = regexp.MustCompile(`\$\(params\.([a-zA-Z0-9_-]+)(?:\.([a-zA-Z0-9_-]+))?\)`)
+
+// paramRef is a single reference found in a ParamSpec's Default string.
+type paramRef struct {
+	name string
+	key  string // non-empty for an object-key reference like $(params.config.region)
+}
+
+func findParamRefs(s string) []paramRef {
+	var refs []paramRef
+	for _, m := range paramRefPattern.FindAllStringSubmatch(s, -1) {
+		refs = append(refs, paramRef{name: m[1], key: m[2]})
+	}
+	return refs
+}
+
+// resolvedParamDefaults is the result of resolving every ParamSpec's Default reference chain.
+type resolvedParamDefaults struct {
+	// Values holds the fully-resolved default for every ParamSpec that has one, whether
+	// literal or derived from a reference chain.
+	Values map[string]v1beta1.ArrayOrString
+	// Unresolved names the ParamSpecs whose Default sits in a reference cycle or references a
+	// param that isn't declared, and so has no resolved value.
+	Unresolved map[string]bool
+	// Errors is one ValidationError per cycle found, per reference to an incompatibly-typed
+	// param, or per reference to a param that isn't declared at all.
+	Errors ValidationErrors
+}
+
+// resolveParamDefaults builds the dependency graph implied by $(params.x) / $(params.x.y)
+// references in each ParamSpec's Default, finds reference cycles with Tarjan's SCC algorithm
+// in O(V+E), and resolves every acyclic reference chain to a concrete default value. Callers
+// that substitute defaults into a TaskSpec can reuse Values instead of re-walking the graph.
+func resolveParamDefaults(paramSpecs []v1beta1.ParamSpec) resolvedParamDefaults {
+	specsByName := make(map[string]v1beta1.ParamSpec, len(paramSpecs))
+	specPath := make(map[string]string, len(paramSpecs))
+	for i, spec := range paramSpecs {
+		specsByName[spec.Name] = spec
+		specPath[spec.Name] = fmt.Sprintf("spec.params[%d]", i)
+	}
+
+	g := newParamRefGraph(paramSpecs)
+	result := resolvedParamDefaults{
+		Values:     make(map[string]v1beta1.ArrayOrString, len(paramSpecs)),
+		Unresolved: make(map[string]bool),
+	}
+	for _, cycle := range g.stronglyConnectedCycles() {
+		for _, name := range cycle {
+			result.Unresolved[name] = true
+		}
+		result.Errors = append(result.Errors, &ValidationError{
+			Kind:    ValidationErrorCycle,
+			Subject: strings.Join(cycle, " -> "),
+			Reason:  "param defaults form a reference cycle",
+			Path:    specPath[cycle[0]],
+		})
+	}
+
+	var resolve func(name string) (v1beta1.ArrayOrString, bool)
+	resolve = func(name string) (v1beta1.ArrayOrString, bool) {
+		if v, ok := result.Values[name]; ok {
+			return v, true
+		}
+		if result.Unresolved[name] {
+			return v1beta1.ArrayOrString{}, false
+		}
+		spec, ok := specsByName[name]
+		if !ok || spec.Default == nil {
+			return v1beta1.ArrayOrString{}, false
+		}
+		if spec.Default.Type != v1beta1.ParamTypeString {
+			// Only string defaults can embed references; arrays/objects resolve as-is.
+			result.Values[name] = *spec.Default
+			return *spec.Default, true
+		}
+
+		resolved := spec.Default.StringVal
+		for _, ref := range findParamRefs(resolved) {
+			target, ok := specsByName[ref.name]
+			if !ok {
+				result.Unresolved[name] = true
+				result.Errors = append(result.Errors, &ValidationError{
+					Kind:    ValidationErrorMissing,
+					Subject: name,
+					Reason:  "default references a param that isn't declared",
+					Detail:  fmt.Sprintf("references %q, which has no ParamSpec", ref.name),
+					Path:    specPath[name],
+				})
+				return v1beta1.ArrayOrString{}, false
+			}
+			if ref.key == "" && target.Type != v1beta1.ParamTypeString {
+				result.Errors = append(result.Errors, &ValidationError{
+					Kind:    ValidationErrorWrongType,
+					Subject: name,
+					Reason:  "string default cannot embed an unindexed reference to a non-string param",
+					Detail:  fmt.Sprintf("references %q, which is type %q", ref.name, target.Type),
+					Path:    specPath[name],
+				})
+				continue
+			}
+			if ref.key != "" && target.Type != v1beta1.ParamTypeObject {
+				result.Errors = append(result.Errors, &ValidationError{
+					Kind:    ValidationErrorWrongType,
+					Subject: name,
+					Reason:  "default references an object key on a non-object param",
+					Detail:  fmt.Sprintf("references %q.%q, but %q is type %q", ref.name, ref.key, ref.name, target.Type),
+					Path:    specPath[name],
+				})
+				continue
+			}
+			targetValue, ok := resolve(ref.name)
+			if !ok {
+				continue
+			}
+			replacement := targetValue.StringVal
+			token := fmt.Sprintf("$(params.%s)", ref.name)
+			if ref.key != "" {
+				replacement = targetValue.ObjectVal[ref.key]
+				token = fmt.Sprintf("$(params.%s.%s)", ref.name, ref.key)
+			}
+			resolved = strings.ReplaceAll(resolved, token, replacement)
+		}
+
+		v := v1beta1.ArrayOrString{Type: v1beta1.ParamTypeString, StringVal: resolved}
+		result.Values[name] = v
+		return v, true
+	}
+
+	for _, spec := range paramSpecs {
+		if result.Unresolved[spec.Name] {
+			continue
+		}
+		resolve(spec.Name)
+	}
+
+	return result
+}
+
+// ResolveParamDefaults resolves the $(params.x) / $(params.x.y) reference chains embedded in
+// each ParamSpec's Default, returning the fully-resolved default value for every ParamSpec
+// that has one. Downstream param substitution can call this once and reuse the result instead
+// of re-walking the reference graph itself.
+func ResolveParamDefaults(paramSpecs []v1beta1.ParamSpec) (map[string]v1beta1.ArrayOrString, error) {
+	resolved := resolveParamDefaults(paramSpecs)
+	var errs *multierror.Error
+	for _, verr := range resolved.Errors {
+		errs = multierror.Append(errs, verr)
+	}
+	return resolved.Values, errs.ErrorOrNil()
+}
+
+// paramRefGraph is the directed graph of ParamSpec name -> referenced ParamSpec names implied
+// by $(params.x) references in string Defaults.
+type paramRefGraph struct {
+	nodes []string
+	edges map[string][]string
+}
+
+func newParamRefGraph(paramSpecs []v1beta1.ParamSpec) *paramRefGraph {
+	g := &paramRefGraph{edges: make(map[string][]string)}
+	for _, spec := range paramSpecs {
+		g.nodes = append(g.nodes, spec.Name)
+		if spec.Default == nil || spec.Default.Type != v1beta1.ParamTypeString {
+			continue
+		}
+		for _, ref := range findParamRefs(spec.Default.StringVal) {
+			g.edges[spec.Name] = append(g.edges[spec.Name], ref.name)
+		}
+	}
+	return g
+}
+
+// stronglyConnectedCycles returns every strongly connected component that represents an
+// actual cycle - size > 1, or a single node with a self-loop - using Tarjan's algorithm so
+// the whole graph is walked in O(V+E) regardless of how many params are declared.
+func (g *paramRefGraph) stronglyConnectedCycles() [][]string {
+	index := 0
+	indices := make(map[string]int)
+	lowlink := make(map[string]int)
+	onStack := make(map[string]bool)
+	var stack []string
+	var sccs [][]string
+
+	var strongconnect func(v string)
+	strongconnect = func(v string) {
+		indices[v] = index
+		lowlink[v] = index
+		index++
+		stack = append(stack, v)
+		onStack[v] = true
+
+		for _, w := range g.edges[v] {
+			if _, visited := indices[w]; !visited {
+				strongconnect(w)
+				if lowlink[w] < lowlink[v] {
+					lowlink[v] = lowlink[w]
+				}
+			} else if onStack[w] {
+				if indices[w] < lowlink[v] {
+					lowlink[v] = indices[w]
+				}
+			}
+		}
+
+		if lowlink[v] == indices[v] {
+			var scc []string
+			for {
+				n := len(stack) - 1
+				w := stack[n]
+				stack = stack[:n]
+				onStack[w] = false
+				scc = append(scc, w)
+				if w == v {
+					break
+				}
+			}
+			sccs = append(sccs, scc)
+		}
+	}
+
+	for _, v := range g.nodes {
+		if _, visited := indices[v]; !visited {
+			strongconnect(v)
+		}
+	}
+
+	var cycles [][]string
+	for _, scc := range sccs {
+		if len(scc) > 1 {
+			cycles = append(cycles, scc)
+			continue
+		}
+		v := scc[0]
+		for _, w := range g.edges[v] {
+			if w == v {
+				cycles = append(cycles, scc)
+				break
+			}
+		}
+	}
+	return cycles
+}
@@ -0,0 +1,116 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package taskrun
+
+import (
+	"testing"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+)
+
+func strParam(name, val string) v1beta1.ParamSpec {
+	return v1beta1.ParamSpec{
+		Name: name, Type: v1beta1.ParamTypeString,
+		Default: &v1beta1.ArrayOrString{Type: v1beta1.ParamTypeString, StringVal: val},
+	}
+}
+
+func TestResolveParamDefaultsChain(t *testing.T) {
+	specs := []v1beta1.ParamSpec{
+		strParam("base", "hello"),
+		strParam("greeting", "$(params.base) world"),
+	}
+	values, err := ResolveParamDefaults(specs)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := values["greeting"].StringVal; got != "hello world" {
+		t.Errorf("greeting = %q, want %q", got, "hello world")
+	}
+}
+
+func TestResolveParamDefaultsObjectKeyRef(t *testing.T) {
+	specs := []v1beta1.ParamSpec{
+		{
+			Name: "config", Type: v1beta1.ParamTypeObject,
+			Default: &v1beta1.ArrayOrString{Type: v1beta1.ParamTypeObject, ObjectVal: map[string]string{"region": "us-east-1"}},
+		},
+		strParam("region", "$(params.config.region)"),
+	}
+	values, err := ResolveParamDefaults(specs)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := values["region"].StringVal; got != "us-east-1" {
+		t.Errorf("region = %q, want %q", got, "us-east-1")
+	}
+}
+
+func TestResolveParamDefaultsCycle(t *testing.T) {
+	specs := []v1beta1.ParamSpec{
+		strParam("a", "$(params.b)"),
+		strParam("b", "$(params.a)"),
+	}
+	values, err := ResolveParamDefaults(specs)
+	if err == nil {
+		t.Fatal("expected an error for a reference cycle")
+	}
+	if _, ok := values["a"]; ok {
+		t.Error("a should remain unresolved when it's part of a cycle")
+	}
+	if _, ok := values["b"]; ok {
+		t.Error("b should remain unresolved when it's part of a cycle")
+	}
+}
+
+func TestResolveParamDefaultsWrongTypeRef(t *testing.T) {
+	specs := []v1beta1.ParamSpec{
+		{
+			Name: "list", Type: v1beta1.ParamTypeArray,
+			Default: &v1beta1.ArrayOrString{Type: v1beta1.ParamTypeArray, ArrayVal: []string{"a", "b"}},
+		},
+		strParam("joined", "$(params.list)"),
+	}
+	_, err := ResolveParamDefaults(specs)
+	if err == nil {
+		t.Fatal("expected an error referencing a non-string param without an object key")
+	}
+}
+
+func TestResolveParamDefaultsDanglingRef(t *testing.T) {
+	specs := []v1beta1.ParamSpec{
+		strParam("greeting", "hello $(params.nonexistent)"),
+	}
+	values, err := ResolveParamDefaults(specs)
+	if err == nil {
+		t.Fatal("expected an error for a reference to an undeclared param")
+	}
+	if v, ok := values["greeting"]; ok {
+		t.Errorf("greeting should remain unresolved for a dangling reference, got %v", v)
+	}
+}
+
+func TestStronglyConnectedCyclesSelfLoop(t *testing.T) {
+	specs := []v1beta1.ParamSpec{
+		strParam("self", "$(params.self)"),
+	}
+	g := newParamRefGraph(specs)
+	cycles := g.stronglyConnectedCycles()
+	if len(cycles) != 1 || len(cycles[0]) != 1 || cycles[0][0] != "self" {
+		t.Errorf("stronglyConnectedCycles() = %v, want a single self-loop on %q", cycles, "self")
+	}
+}
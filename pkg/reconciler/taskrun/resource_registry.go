@@ -0,0 +1,175 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package taskrun
+
+import (
+	"fmt"
+	"net/url"
+	"sync"
+
+	resourcev1alpha1 "github.com/tektoncd/pipeline/pkg/apis/resource/v1alpha1"
+)
+
+// ResourceChecker validates that a provided PipelineResource is compatible with, and
+// structurally valid for, a Task's declared resource type.
+type ResourceChecker interface {
+	// Compatible reports whether declaredType (the type a Task's TaskResource asked for) is
+	// satisfied by this checker, letting a checker recognize aliases of its primary type
+	// (e.g. "github" as an alias of "git").
+	Compatible(declaredType resourcev1alpha1.PipelineResourceType) bool
+	// Check performs structural validation of r beyond type equality: required params,
+	// secret refs, well-formed URLs, and so on.
+	Check(name string, declaredType resourcev1alpha1.PipelineResourceType, r *resourcev1alpha1.PipelineResource) error
+}
+
+type registeredResourceChecker struct {
+	name    string
+	checker ResourceChecker
+}
+
+var (
+	resourceTypeRegistryMu sync.RWMutex
+	resourceTypeRegistry   = []registeredResourceChecker{
+		{name: "git", checker: gitResourceChecker{}},
+		{name: "image", checker: imageResourceChecker{}},
+		{name: "storage", checker: storageResourceChecker{}},
+		{name: "pullRequest", checker: pullRequestResourceChecker{}},
+	}
+)
+
+// RegisterResourceType adds checker to the ResourceTypeRegistry under name, so operators can
+// validate custom PipelineResource kinds - or replace one of the four built-ins - without
+// patching this package.
+func RegisterResourceType(name string, checker ResourceChecker) {
+	resourceTypeRegistryMu.Lock()
+	defer resourceTypeRegistryMu.Unlock()
+	for i, rc := range resourceTypeRegistry {
+		if rc.name == name {
+			resourceTypeRegistry[i].checker = checker
+			return
+		}
+	}
+	resourceTypeRegistry = append(resourceTypeRegistry, registeredResourceChecker{name: name, checker: checker})
+}
+
+// checkResourceType validates r against declaredType. It consults the ResourceTypeRegistry
+// for a checker that declares itself Compatible with declaredType and, if one exists, defers
+// to its (potentially deeper) validation. When no checker claims declaredType it falls back
+// to the historical shallow equality check, so resource kinds nobody has registered a checker
+// for keep behaving exactly as before.
+func checkResourceType(name string, declaredType resourcev1alpha1.PipelineResourceType, r *resourcev1alpha1.PipelineResource) error {
+	resourceTypeRegistryMu.RLock()
+	defer resourceTypeRegistryMu.RUnlock()
+	for _, rc := range resourceTypeRegistry {
+		if rc.checker.Compatible(declaredType) {
+			return rc.checker.Check(name, declaredType, r)
+		}
+	}
+	if declaredType != r.Spec.Type {
+		return fmt.Errorf("should be type %q but was %q", r.Spec.Type, declaredType)
+	}
+	return nil
+}
+
+// resourceParamValue returns the value of the named param on r's spec, if present.
+func resourceParamValue(r *resourcev1alpha1.PipelineResource, name string) (string, bool) {
+	for _, p := range r.Spec.Params {
+		if p.Name == name {
+			return p.Value, true
+		}
+	}
+	return "", false
+}
+
+// gitResourceChecker accepts "git" and its "github" alias, requiring a well-formed "url" param.
+type gitResourceChecker struct{}
+
+func (gitResourceChecker) Compatible(declaredType resourcev1alpha1.PipelineResourceType) bool {
+	return declaredType == "git" || declaredType == "github"
+}
+
+func (gitResourceChecker) Check(name string, declaredType resourcev1alpha1.PipelineResourceType, r *resourcev1alpha1.PipelineResource) error {
+	if !(gitResourceChecker{}).Compatible(r.Spec.Type) {
+		return fmt.Errorf("resource %q should be type %q but was %q", name, declaredType, r.Spec.Type)
+	}
+	rawURL, ok := resourceParamValue(r, "url")
+	if !ok || rawURL == "" {
+		return fmt.Errorf("git resource %q must set a non-empty %q param", name, "url")
+	}
+	if _, err := url.ParseRequestURI(rawURL); err != nil {
+		return fmt.Errorf("git resource %q has a malformed %q param %q: %w", name, "url", rawURL, err)
+	}
+	return nil
+}
+
+// imageResourceChecker requires a non-empty "url" param naming the image to pull/push; the
+// format of that value is not checked.
+type imageResourceChecker struct{}
+
+func (imageResourceChecker) Compatible(declaredType resourcev1alpha1.PipelineResourceType) bool {
+	return declaredType == "image"
+}
+
+func (imageResourceChecker) Check(name string, declaredType resourcev1alpha1.PipelineResourceType, r *resourcev1alpha1.PipelineResource) error {
+	if r.Spec.Type != "image" {
+		return fmt.Errorf("resource %q should be type %q but was %q", name, declaredType, r.Spec.Type)
+	}
+	rawURL, ok := resourceParamValue(r, "url")
+	if !ok || rawURL == "" {
+		return fmt.Errorf("image resource %q must set a non-empty %q param", name, "url")
+	}
+	return nil
+}
+
+// storageResourceChecker requires a non-empty "location" param. The storage "type" param
+// (e.g. "gcs") is not checked.
+type storageResourceChecker struct{}
+
+func (storageResourceChecker) Compatible(declaredType resourcev1alpha1.PipelineResourceType) bool {
+	return declaredType == "storage"
+}
+
+func (storageResourceChecker) Check(name string, declaredType resourcev1alpha1.PipelineResourceType, r *resourcev1alpha1.PipelineResource) error {
+	if r.Spec.Type != "storage" {
+		return fmt.Errorf("resource %q should be type %q but was %q", name, declaredType, r.Spec.Type)
+	}
+	if location, ok := resourceParamValue(r, "location"); !ok || location == "" {
+		return fmt.Errorf("storage resource %q must set a non-empty %q param", name, "location")
+	}
+	return nil
+}
+
+// pullRequestResourceChecker requires a well-formed "url" param pointing at the pull request.
+type pullRequestResourceChecker struct{}
+
+func (pullRequestResourceChecker) Compatible(declaredType resourcev1alpha1.PipelineResourceType) bool {
+	return declaredType == "pullRequest"
+}
+
+func (pullRequestResourceChecker) Check(name string, declaredType resourcev1alpha1.PipelineResourceType, r *resourcev1alpha1.PipelineResource) error {
+	if r.Spec.Type != "pullRequest" {
+		return fmt.Errorf("resource %q should be type %q but was %q", name, declaredType, r.Spec.Type)
+	}
+	rawURL, ok := resourceParamValue(r, "url")
+	if !ok || rawURL == "" {
+		return fmt.Errorf("pullRequest resource %q must set a non-empty %q param", name, "url")
+	}
+	if _, err := url.ParseRequestURI(rawURL); err != nil {
+		return fmt.Errorf("pullRequest resource %q has a malformed %q param %q: %w", name, "url", rawURL, err)
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package taskrun
+
+import (
+	"testing"
+
+	resourcev1alpha1 "github.com/tektoncd/pipeline/pkg/apis/resource/v1alpha1"
+)
+
+func TestCheckResourceTypeGitAlias(t *testing.T) {
+	r := &resourcev1alpha1.PipelineResource{
+		Spec: resourcev1alpha1.PipelineResourceSpec{
+			Type:   "github",
+			Params: []resourcev1alpha1.ResourceParam{{Name: "url", Value: "https://github.com/tektoncd/pipeline"}},
+		},
+	}
+	if err := checkResourceType("repo", "git", r); err != nil {
+		t.Errorf("unexpected error validating a github-aliased git resource: %v", err)
+	}
+}
+
+func TestCheckResourceTypeGitMissingURL(t *testing.T) {
+	r := &resourcev1alpha1.PipelineResource{Spec: resourcev1alpha1.PipelineResourceSpec{Type: "git"}}
+	if err := checkResourceType("repo", "git", r); err == nil {
+		t.Error("expected an error for a git resource with no url param")
+	}
+}
+
+func TestCheckResourceTypeFallsBackToShallowEquality(t *testing.T) {
+	r := &resourcev1alpha1.PipelineResource{Spec: resourcev1alpha1.PipelineResourceSpec{Type: "cluster"}}
+	if err := checkResourceType("c", "cluster", r); err != nil {
+		t.Errorf("unexpected error for an unregistered type with matching Spec.Type: %v", err)
+	}
+	if err := checkResourceType("c", "cluster", &resourcev1alpha1.PipelineResource{Spec: resourcev1alpha1.PipelineResourceSpec{Type: "storage"}}); err == nil {
+		t.Error("expected an error for an unregistered type with mismatched Spec.Type")
+	}
+}
+
+type alwaysOKChecker struct{}
+
+func (alwaysOKChecker) Compatible(declaredType resourcev1alpha1.PipelineResourceType) bool {
+	return declaredType == "custom"
+}
+
+func (alwaysOKChecker) Check(name string, declaredType resourcev1alpha1.PipelineResourceType, r *resourcev1alpha1.PipelineResource) error {
+	return nil
+}
+
+func TestRegisterResourceTypeCustom(t *testing.T) {
+	RegisterResourceType("custom", alwaysOKChecker{})
+	r := &resourcev1alpha1.PipelineResource{Spec: resourcev1alpha1.PipelineResourceSpec{Type: "wrong-type-entirely"}}
+	if err := checkResourceType("c", "custom", r); err != nil {
+		t.Errorf("expected the registered checker to accept anything, got: %v", err)
+	}
+}
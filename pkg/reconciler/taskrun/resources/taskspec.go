@@ -0,0 +1,31 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	resourcev1alpha1 "github.com/tektoncd/pipeline/pkg/apis/resource/v1alpha1"
+)
+
+// ResolvedTaskResources captures a Task (or embedded TaskSpec) with its declared
+// PipelineResources resolved to concrete objects, ready for validation and execution.
+type ResolvedTaskResources struct {
+	TaskName string
+	TaskSpec *v1beta1.TaskSpec
+	Inputs   map[string]*resourcev1alpha1.PipelineResource
+	Outputs  map[string]*resourcev1alpha1.PipelineResource
+}
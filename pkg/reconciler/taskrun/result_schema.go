@@ -0,0 +1,121 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package taskrun
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+)
+
+// ResultSchemaValidator validates that a TaskRun's emitted results conform to the shape
+// required by a particular provenance BuildType (e.g. "https://slsa.dev/provenance/v0.2").
+// Implementations should treat results that are irrelevant to their schema as valid; they
+// only need to reject results that claim to satisfy the schema but don't.
+type ResultSchemaValidator interface {
+	ValidateResults(tr *v1beta1.TaskRun, specResults []v1beta1.TaskResult) error
+}
+
+var (
+	resultSchemaRegistryMu sync.RWMutex
+	resultSchemaRegistry   = map[string]ResultSchemaValidator{
+		"slsa":   slsaResultSchema{},
+		"tekton": tektonResultSchema{},
+	}
+)
+
+// RegisterResultSchema registers v as the ResultSchemaValidator used for TaskRuns/Tasks that
+// declare buildType as their results' BuildType. Registering under an already-registered
+// buildType overwrites the previous validator, so downstream consumers can override either of
+// the built-in "slsa" and "tekton" schemas with their own.
+func RegisterResultSchema(buildType string, v ResultSchemaValidator) {
+	resultSchemaRegistryMu.Lock()
+	defer resultSchemaRegistryMu.Unlock()
+	resultSchemaRegistry[buildType] = v
+}
+
+func lookupResultSchema(buildType string) (ResultSchemaValidator, bool) {
+	resultSchemaRegistryMu.RLock()
+	defer resultSchemaRegistryMu.RUnlock()
+	v, ok := resultSchemaRegistry[buildType]
+	return v, ok
+}
+
+// validateResultsBuildType dispatches to the ResultSchemaValidator registered for buildType,
+// if any. An empty buildType is not validated: schema validation is opt-in, and Tasks that
+// don't declare one keep today's untyped-results behavior.
+func validateResultsBuildType(buildType string, tr *v1beta1.TaskRun, specResults []v1beta1.TaskResult) error {
+	if buildType == "" {
+		return nil
+	}
+	schema, ok := lookupResultSchema(buildType)
+	if !ok {
+		return fmt.Errorf("no result schema is registered for buildType %q", buildType)
+	}
+	return schema.ValidateResults(tr, specResults)
+}
+
+// slsaResultSchema is the minimal built-in schema for the "slsa" buildType: a TaskRun that
+// publishes any image provenance must publish both IMAGE_URL and IMAGE_DIGEST as strings.
+type slsaResultSchema struct{}
+
+func (slsaResultSchema) ValidateResults(tr *v1beta1.TaskRun, specResults []v1beta1.TaskResult) error {
+	emitted := make(map[string]v1beta1.TaskRunResult, len(tr.Status.TaskRunResults))
+	for _, r := range tr.Status.TaskRunResults {
+		emitted[r.Name] = r
+	}
+	url, hasURL := emitted["IMAGE_URL"]
+	digest, hasDigest := emitted["IMAGE_DIGEST"]
+	if !hasURL && !hasDigest {
+		// Nothing image-related was produced; the slsa schema has nothing further to say.
+		return nil
+	}
+	if !hasURL || !hasDigest {
+		return fmt.Errorf("slsa result schema requires both IMAGE_URL and IMAGE_DIGEST results, got IMAGE_URL=%t IMAGE_DIGEST=%t", hasURL, hasDigest)
+	}
+	if url.Value.Type != v1beta1.ParamTypeString || digest.Value.Type != v1beta1.ParamTypeString {
+		return fmt.Errorf("slsa result schema requires IMAGE_URL and IMAGE_DIGEST to be strings")
+	}
+	return nil
+}
+
+// tektonResultSchema is the richer built-in schema for the "tekton" buildType. In addition to
+// the slsa image checks, it requires an ARTIFACT_OUTPUTS object result carrying "uri" and
+// "digest" keys whenever the TaskRun reports one. Step and sidecar image digests are not
+// checked.
+type tektonResultSchema struct{}
+
+func (s tektonResultSchema) ValidateResults(tr *v1beta1.TaskRun, specResults []v1beta1.TaskResult) error {
+	if err := (slsaResultSchema{}).ValidateResults(tr, specResults); err != nil {
+		return err
+	}
+	for _, r := range tr.Status.TaskRunResults {
+		if r.Name != "ARTIFACT_OUTPUTS" {
+			continue
+		}
+		if r.Value.Type != v1beta1.ParamTypeObject {
+			return fmt.Errorf("tekton result schema requires ARTIFACT_OUTPUTS to be an object, got %s", r.Value.Type)
+		}
+		for _, key := range []string{"uri", "digest"} {
+			if _, ok := r.Value.ObjectVal[key]; !ok {
+				return fmt.Errorf("tekton result schema requires ARTIFACT_OUTPUTS to have a %q key", key)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package taskrun
+
+import (
+	"testing"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+)
+
+func taskRunResult(name string, v v1beta1.ArrayOrString) v1beta1.TaskRunResult {
+	return v1beta1.TaskRunResult{Name: name, Value: v}
+}
+
+func TestValidateResultsBuildTypeEmptyIsNoop(t *testing.T) {
+	if err := validateResultsBuildType("", &v1beta1.TaskRun{}, nil); err != nil {
+		t.Errorf("unexpected error for an empty buildType: %v", err)
+	}
+}
+
+func TestValidateResultsBuildTypeUnregistered(t *testing.T) {
+	if err := validateResultsBuildType("no-such-schema", &v1beta1.TaskRun{}, nil); err == nil {
+		t.Error("expected an error for an unregistered buildType")
+	}
+}
+
+func TestSLSAResultSchemaRequiresBothImageResults(t *testing.T) {
+	tr := &v1beta1.TaskRun{Status: v1beta1.TaskRunStatus{TaskRunResults: []v1beta1.TaskRunResult{
+		taskRunResult("IMAGE_URL", v1beta1.ArrayOrString{Type: v1beta1.ParamTypeString, StringVal: "gcr.io/x/y"}),
+	}}}
+	if err := validateResultsBuildType("slsa", tr, nil); err == nil {
+		t.Error("expected an error when only IMAGE_URL is present")
+	}
+}
+
+func TestSLSAResultSchemaPassesWithBoth(t *testing.T) {
+	tr := &v1beta1.TaskRun{Status: v1beta1.TaskRunStatus{TaskRunResults: []v1beta1.TaskRunResult{
+		taskRunResult("IMAGE_URL", v1beta1.ArrayOrString{Type: v1beta1.ParamTypeString, StringVal: "gcr.io/x/y"}),
+		taskRunResult("IMAGE_DIGEST", v1beta1.ArrayOrString{Type: v1beta1.ParamTypeString, StringVal: "sha256:abc"}),
+	}}}
+	if err := validateResultsBuildType("slsa", tr, nil); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestTektonResultSchemaRequiresArtifactOutputsKeys(t *testing.T) {
+	tr := &v1beta1.TaskRun{Status: v1beta1.TaskRunStatus{TaskRunResults: []v1beta1.TaskRunResult{
+		taskRunResult("ARTIFACT_OUTPUTS", v1beta1.ArrayOrString{Type: v1beta1.ParamTypeObject, ObjectVal: map[string]string{"uri": "x"}}),
+	}}}
+	if err := validateResultsBuildType("tekton", tr, nil); err == nil {
+		t.Error("expected an error when ARTIFACT_OUTPUTS is missing the digest key")
+	}
+}
+
+func TestRegisterResultSchemaCustom(t *testing.T) {
+	RegisterResultSchema("custom", tektonResultSchema{})
+	tr := &v1beta1.TaskRun{}
+	if err := validateResultsBuildType("custom", tr, nil); err != nil {
+		t.Errorf("unexpected error from the registered custom schema: %v", err)
+	}
+}
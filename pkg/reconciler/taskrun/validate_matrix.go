@@ -0,0 +1,148 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package taskrun
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+
+	"github.com/hashicorp/go-multierror"
+)
+
+// validateMatrix checks that every matrix Param carries an ArrayVal, that the Cartesian
+// product of all matrix params' values doesn't exceed the max-matrix-combinations feature
+// flag, that the ParamSpec each matrix param fans into is String-typed (a matrix element
+// binds to a single string value, so Array- and Object-typed targets can never receive one),
+// and that the resulting combinations are free of exact duplicates. A malformed matrix today
+// silently generates its (possibly enormous) Cartesian product of child TaskRuns, most of
+// which then fail one by one; catching it here instead surfaces every problem up front.
+func validateMatrix(ctx context.Context, matrix []v1beta1.Param, paramSpecs []v1beta1.ParamSpec) error {
+	if len(matrix) == 0 {
+		return nil
+	}
+
+	specsByName := make(map[string]v1beta1.ParamSpec, len(paramSpecs))
+	for _, spec := range paramSpecs {
+		specsByName[spec.Name] = spec
+	}
+
+	var errs *multierror.Error
+	combinations := 1
+	for _, param := range matrix {
+		if param.Value.Type != v1beta1.ParamTypeArray {
+			errs = multierror.Append(errs, &ValidationError{
+				Kind:    ValidationErrorWrongType,
+				Subject: param.Name,
+				Reason:  "matrix params must be arrays",
+				Detail:  fmt.Sprintf("got type %q", param.Value.Type),
+				Path:    paramSpecPath(paramSpecs, param.Name),
+			})
+			continue
+		}
+		combinations *= len(param.Value.ArrayVal)
+
+		spec, ok := specsByName[param.Name]
+		if !ok {
+			// Unknown params are reported by extraParamsNames.
+			continue
+		}
+		if verr := validateMatrixElementType(param.Name, spec); verr != nil {
+			verr.Path = paramSpecPath(paramSpecs, param.Name)
+			errs = multierror.Append(errs, verr)
+		}
+	}
+
+	maxCombinations := config.FromContextOrDefaults(ctx).FeatureFlags.MaxMatrixCombinationsSize
+	if combinations > maxCombinations {
+		errs = multierror.Append(errs, &ValidationError{
+			Kind:    ValidationErrorMismatch,
+			Subject: "matrix",
+			Reason:  "matrix generates more combinations than max-matrix-combinations allows",
+			Detail:  fmt.Sprintf("%d combinations, limit is %d", combinations, maxCombinations),
+			Path:    "spec.matrix",
+		})
+	} else if dupes := duplicateMatrixCombinations(matrix); len(dupes) != 0 {
+		// Only worth computing once we know the full product is bounded.
+		errs = multierror.Append(errs, &ValidationError{
+			Kind:    ValidationErrorMismatch,
+			Subject: "matrix",
+			Reason:  "matrix contains duplicate combinations",
+			Detail:  strings.Join(dupes, "; "),
+			Path:    "spec.matrix",
+		})
+	}
+
+	return errs.ErrorOrNil()
+}
+
+// validateMatrixElementType validates that the ParamSpec a matrix Param fans into is
+// String-typed. A matrix element is always a single string pulled from the Param's ArrayVal,
+// so it can only ever bind to a String-typed ParamSpec - Array- and Object-typed targets
+// would receive a value whose shape they can't use, which used to be rejected by
+// wrongTypeParamsNames before matrix validation moved into this file.
+func validateMatrixElementType(paramName string, spec v1beta1.ParamSpec) *ValidationError {
+	if spec.Type == v1beta1.ParamTypeString {
+		return nil
+	}
+	return &ValidationError{
+		Kind:    ValidationErrorWrongType,
+		Subject: paramName,
+		Reason:  "matrix params can only fan out into string-typed ParamSpecs",
+		Detail:  fmt.Sprintf("ParamSpec %q is type %q", spec.Name, spec.Type),
+	}
+}
+
+// duplicateMatrixCombinations describes each combination that repeats an earlier one, over
+// the Cartesian product of every matrix param's ArrayVal in declaration order - the same
+// order fan-out uses to generate child TaskRuns.
+func duplicateMatrixCombinations(matrix []v1beta1.Param) []string {
+	combos := cartesianProduct(matrix)
+	seen := make(map[string]int, len(combos))
+	var dupes []string
+	for i, combo := range combos {
+		key := strings.Join(combo, "\x1f")
+		if first, ok := seen[key]; ok {
+			dupes = append(dupes, fmt.Sprintf("combination %d duplicates combination %d: %v", i, first, combo))
+			continue
+		}
+		seen[key] = i
+	}
+	return dupes
+}
+
+// cartesianProduct enumerates every combination of the ArrayVal entries across matrix params.
+func cartesianProduct(matrix []v1beta1.Param) [][]string {
+	combos := [][]string{{}}
+	for _, param := range matrix {
+		if param.Value.Type != v1beta1.ParamTypeArray {
+			continue
+		}
+		var next [][]string
+		for _, combo := range combos {
+			for _, val := range param.Value.ArrayVal {
+				extended := append(append([]string{}, combo...), val)
+				next = append(next, extended)
+			}
+		}
+		combos = next
+	}
+	return combos
+}
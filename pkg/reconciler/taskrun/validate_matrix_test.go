@@ -0,0 +1,82 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package taskrun
+
+import (
+	"context"
+	"testing"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+)
+
+func arrayParam(name string, vals ...string) v1beta1.Param {
+	return v1beta1.Param{Name: name, Value: v1beta1.ArrayOrString{Type: v1beta1.ParamTypeArray, ArrayVal: vals}}
+}
+
+func TestValidateMatrixRejectsNonStringTarget(t *testing.T) {
+	matrix := []v1beta1.Param{arrayParam("platform", "linux", "darwin")}
+	specs := []v1beta1.ParamSpec{{Name: "platform", Type: v1beta1.ParamTypeArray}}
+	if err := validateMatrix(context.Background(), matrix, specs); err == nil {
+		t.Fatal("expected an error when a matrix param fans into an Array-typed ParamSpec")
+	}
+}
+
+func TestValidateMatrixAcceptsStringTarget(t *testing.T) {
+	matrix := []v1beta1.Param{arrayParam("platform", "linux", "darwin")}
+	specs := []v1beta1.ParamSpec{{Name: "platform", Type: v1beta1.ParamTypeString}}
+	if err := validateMatrix(context.Background(), matrix, specs); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestValidateMatrixCombinationLimit(t *testing.T) {
+	matrix := []v1beta1.Param{arrayParam("a", "1", "2", "3"), arrayParam("b", "1", "2", "3")}
+	ctx := config.ToContext(context.Background(), &config.Config{FeatureFlags: &config.FeatureFlags{MaxMatrixCombinationsSize: 4}})
+	if err := validateMatrix(ctx, matrix, nil); err == nil {
+		t.Fatal("expected an error when combinations exceed MaxMatrixCombinationsSize")
+	}
+}
+
+func TestCartesianProduct(t *testing.T) {
+	matrix := []v1beta1.Param{arrayParam("a", "1", "2"), arrayParam("b", "x", "y")}
+	got := cartesianProduct(matrix)
+	want := [][]string{{"1", "x"}, {"1", "y"}, {"2", "x"}, {"2", "y"}}
+	if len(got) != len(want) {
+		t.Fatalf("cartesianProduct() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if len(got[i]) != len(want[i]) || got[i][0] != want[i][0] || got[i][1] != want[i][1] {
+			t.Errorf("cartesianProduct()[%d] = %v, want %v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestDuplicateMatrixCombinations(t *testing.T) {
+	matrix := []v1beta1.Param{arrayParam("a", "1", "1"), arrayParam("b", "x", "x")}
+	dupes := duplicateMatrixCombinations(matrix)
+	if len(dupes) == 0 {
+		t.Fatal("expected duplicate combinations to be detected")
+	}
+}
+
+func TestDuplicateMatrixCombinationsNoDupes(t *testing.T) {
+	matrix := []v1beta1.Param{arrayParam("a", "1", "2"), arrayParam("b", "x", "y")}
+	if dupes := duplicateMatrixCombinations(matrix); len(dupes) != 0 {
+		t.Errorf("expected no duplicates, got %v", dupes)
+	}
+}
@@ -25,12 +25,17 @@ import (
 	resourcev1alpha1 "github.com/tektoncd/pipeline/pkg/apis/resource/v1alpha1"
 	"github.com/tektoncd/pipeline/pkg/list"
 	"github.com/tektoncd/pipeline/pkg/reconciler/taskrun/resources"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 
 	"github.com/hashicorp/go-multierror"
 )
 
-func validateResources(requiredResources []v1beta1.TaskResource, providedResources map[string]*resourcev1alpha1.PipelineResource) error {
+// validateResources validates requiredResources against providedResources. direction ("inputs"
+// or "outputs") distinguishes which side of the Task's TaskResources this call is checking, so
+// that a TaskRun missing both an input and an output resource produces two distinguishable
+// entries in the aggregated ValidationErrors rather than two identical "resources" ones.
+func validateResources(direction string, requiredResources []v1beta1.TaskResource, providedResources map[string]*resourcev1alpha1.PipelineResource) error {
 	required := make([]string, 0, len(requiredResources))
 	optional := make([]string, 0, len(requiredResources))
 	for _, resource := range requiredResources {
@@ -46,46 +51,125 @@ func validateResources(requiredResources []v1beta1.TaskResource, providedResourc
 	for resource := range providedResources {
 		provided = append(provided, resource)
 	}
+
+	subject := fmt.Sprintf("resources.%s", direction)
+	var errs *multierror.Error
 	// verify that the list of required resources does exist in the provided resources
 	missing := list.DiffLeft(required, provided)
 	if len(missing) > 0 {
-		return fmt.Errorf("Task's declared required resources are missing from the TaskRun: %s", missing)
+		errs = multierror.Append(errs, &ValidationError{
+			Kind:    ValidationErrorMissing,
+			Subject: subject,
+			Reason:  "Task's declared required resources are missing from the TaskRun",
+			Detail:  fmt.Sprintf("%s", missing),
+			Path:    fmt.Sprintf("spec.resources.%s", direction),
+		})
 	}
 	// verify that the list of provided resources does not have any extra resources (outside of required and optional resources combined)
 	extra := list.DiffLeft(provided, append(required, optional...))
 	if len(extra) > 0 {
-		return fmt.Errorf("TaskRun's declared resources didn't match usage in Task: %s", extra)
-	}
-	for _, resource := range requiredResources {
+		errs = multierror.Append(errs, &ValidationError{
+			Kind:    ValidationErrorExtra,
+			Subject: subject,
+			Reason:  "TaskRun's declared resources didn't match usage in Task",
+			Detail:  fmt.Sprintf("%s", extra),
+			Path:    fmt.Sprintf("spec.resources.%s", direction),
+		})
+	}
+	for i, resource := range requiredResources {
+		path := fmt.Sprintf("spec.resources.%s[%d]", direction, i)
 		r := providedResources[resource.Name]
 		if !resource.Optional && r == nil {
 			// This case should never be hit due to the check for missing resources at the beginning of the function
-			return fmt.Errorf("resource %q is missing", resource.Name)
+			// A resource absent from providedResources was already reported by the missing check.
+			if _, listed := providedResources[resource.Name]; listed {
+				errs = multierror.Append(errs, &ValidationError{
+					Kind:    ValidationErrorMissing,
+					Subject: resource.Name,
+					Reason:  "resource is missing",
+					Path:    path,
+				})
+			}
+			continue
 		}
-		if r != nil && resource.Type != r.Spec.Type {
-			return fmt.Errorf("resource %q should be type %q but was %q", resource.Name, r.Spec.Type, resource.Type)
+		if r != nil {
+			if err := checkResourceType(resource.Name, resource.Type, r); err != nil {
+				errs = multierror.Append(errs, &ValidationError{
+					Kind:    ValidationErrorWrongType,
+					Subject: resource.Name,
+					Reason:  "resource didn't pass its type's validation",
+					Detail:  err.Error(),
+					Path:    path,
+				})
+			}
 		}
 	}
-	return nil
+	return errs.ErrorOrNil()
 }
 
-func validateParams(ctx context.Context, paramSpecs []v1beta1.ParamSpec, params []v1beta1.Param, matrix []v1beta1.Param) error {
+// validateParams validates paramSpecs, params and matrix against each other, returning every
+// violation found. It also returns the ParamSpecs' resolved default values (including defaults
+// that reference other params' defaults, per resolveParamDefaults) so that callers performing
+// variable substitution afterward don't need to re-resolve the same cross-param references.
+func validateParams(ctx context.Context, paramSpecs []v1beta1.ParamSpec, params []v1beta1.Param, matrix []v1beta1.Param) (map[string]v1beta1.ArrayOrString, error) {
+	var errs *multierror.Error
 	neededParamsNames, neededParamsTypes := neededParamsNamesAndTypes(paramSpecs)
 	providedParamsNames := providedParamsNames(append(params, matrix...))
-	if missingParamsNames := missingParamsNames(neededParamsNames, providedParamsNames, paramSpecs); len(missingParamsNames) != 0 {
-		return fmt.Errorf("missing values for these params which have no default values: %s", missingParamsNames)
-	}
-	if extraParamsNames := extraParamsNames(ctx, neededParamsNames, providedParamsNames); len(extraParamsNames) != 0 {
-		return fmt.Errorf("didn't need these params but they were provided anyway: %s", extraParamsNames)
-	}
-	if wrongTypeParamNames := wrongTypeParamsNames(params, matrix, neededParamsTypes); len(wrongTypeParamNames) != 0 {
-		return fmt.Errorf("param types don't match the user-specified type: %s", wrongTypeParamNames)
-	}
-	if missingKeysObjectParamNames := MissingKeysObjectParamNames(paramSpecs, params); len(missingKeysObjectParamNames) != 0 {
-		return fmt.Errorf("missing keys for these params which are required in ParamSpec's properties %v", missingKeysObjectParamNames)
-	}
+	resolvedDefaults := resolveParamDefaults(paramSpecs)
+	for _, name := range missingParamsNames(neededParamsNames, providedParamsNames, paramSpecs, resolvedDefaults.Unresolved) {
+		errs = multierror.Append(errs, &ValidationError{
+			Kind:    ValidationErrorMissing,
+			Subject: name,
+			Reason:  "no value provided and the ParamSpec declares no resolvable default",
+			Path:    paramSpecPath(paramSpecs, name),
+		})
+	}
+	for _, verr := range resolvedDefaults.Errors {
+		errs = multierror.Append(errs, verr)
+	}
+	for _, name := range extraParamsNames(ctx, neededParamsNames, providedParamsNames) {
+		errs = multierror.Append(errs, &ValidationError{
+			Kind:    ValidationErrorExtra,
+			Subject: name,
+			Reason:  "param was provided but is not declared by the Task",
+		})
+	}
+	for _, name := range wrongTypeParamsNames(params, matrix, neededParamsTypes) {
+		errs = multierror.Append(errs, &ValidationError{
+			Kind:    ValidationErrorWrongType,
+			Subject: name,
+			Reason:  "param type doesn't match the type declared in ParamSpec",
+			Path:    paramSpecPath(paramSpecs, name),
+		})
+	}
+	for name, keys := range MissingKeysObjectParamNames(paramSpecs, params) {
+		errs = multierror.Append(errs, &ValidationError{
+			Kind:    ValidationErrorMissingKey,
+			Subject: name,
+			Reason:  "object param is missing keys required by ParamSpec's properties",
+			Detail:  fmt.Sprintf("%v", keys),
+			Path:    paramSpecPath(paramSpecs, name),
+		})
+	}
+	for _, verr := range validateParamCELExpressions(paramSpecs, params, matrix) {
+		errs = multierror.Append(errs, verr)
+	}
+	if err := validateMatrix(ctx, matrix, paramSpecs); err != nil {
+		errs = multierror.Append(errs, err)
+	}
+
+	return resolvedDefaults.Values, errs.ErrorOrNil()
+}
 
-	return nil
+// paramSpecPath locates name's ParamSpec within paramSpecs, returning e.g. "spec.params[2]", or
+// "" if no ParamSpec declares that name (e.g. an extra param has nothing to point at).
+func paramSpecPath(paramSpecs []v1beta1.ParamSpec, name string) string {
+	for i, spec := range paramSpecs {
+		if spec.Name == name {
+			return fmt.Sprintf("spec.params[%d]", i)
+		}
+	}
+	return ""
 }
 
 func neededParamsNamesAndTypes(paramSpecs []v1beta1.ParamSpec) ([]string, map[string]v1beta1.ParamType) {
@@ -107,12 +191,16 @@ func providedParamsNames(params []v1beta1.Param) []string {
 	return providedParamsNames
 }
 
-func missingParamsNames(neededParams []string, providedParams []string, paramSpecs []v1beta1.ParamSpec) []string {
+// missingParamsNames returns the needed params that were neither provided nor given a
+// resolvable default: a ParamSpec with no Default is missing as before, and one whose Default
+// sits in a reference cycle or references an undeclared param (unresolvable, per unresolved)
+// is now treated the same way.
+func missingParamsNames(neededParams []string, providedParams []string, paramSpecs []v1beta1.ParamSpec, unresolved map[string]bool) []string {
 	missingParamsNames := list.DiffLeft(neededParams, providedParams)
 	var missingParamsNamesWithNoDefaults []string
 	for _, param := range missingParamsNames {
 		for _, inputResourceParam := range paramSpecs {
-			if inputResourceParam.Name == param && inputResourceParam.Default == nil {
+			if inputResourceParam.Name == param && (inputResourceParam.Default == nil || unresolved[param]) {
 				missingParamsNamesWithNoDefaults = append(missingParamsNamesWithNoDefaults, param)
 			}
 		}
@@ -144,16 +232,9 @@ func wrongTypeParamsNames(params []v1beta1.Param, matrix []v1beta1.Param, needed
 			wrongTypeParamNames = append(wrongTypeParamNames, param.Name)
 		}
 	}
-	for _, param := range matrix {
-		if _, ok := neededParamsTypes[param.Name]; !ok {
-			// Ignore any missing params - this happens when extra params were
-			// passed to the task that aren't being used.
-			continue
-		}
-		if neededParamsTypes[param.Name] != v1beta1.ParamTypeString {
-			wrongTypeParamNames = append(wrongTypeParamNames, param.Name)
-		}
-	}
+	// matrix params fan out rather than bind directly, so the type of the ParamSpec they fan
+	// into is checked separately by validateMatrix instead of here; object property keys are
+	// not checked for matrix params.
 	return wrongTypeParamNames
 }
 
@@ -200,10 +281,17 @@ func findMissingKeys(neededKeys, providedKeys map[string][]string) map[string][]
 	return missings
 }
 
-// ValidateResolvedTaskResources validates task inputs, params and output matches taskrun
-func ValidateResolvedTaskResources(ctx context.Context, params []v1beta1.Param, matrix []v1beta1.Param, rtr *resources.ResolvedTaskResources) error {
-	if err := validateParams(ctx, rtr.TaskSpec.Params, params, matrix); err != nil {
-		return fmt.Errorf("invalid input params for task %s: %w", rtr.TaskName, err)
+// ValidateResolvedTaskResources validates task inputs, params and output matches taskrun. It
+// aggregates every violation it finds into a single error rather than failing on the first
+// one, and returns that aggregate pre-encoded as a JSON document so a TaskRun's condition can
+// report every problem in one round-trip instead of making the user fix-and-retry once per
+// violation. It also returns the Task's resolved param defaults so a caller proceeding to
+// variable substitution doesn't have to resolve cross-param default references a second time.
+func ValidateResolvedTaskResources(ctx context.Context, params []v1beta1.Param, matrix []v1beta1.Param, rtr *resources.ResolvedTaskResources) (map[string]v1beta1.ArrayOrString, string, error) {
+	var errs *multierror.Error
+	resolvedDefaults, err := validateParams(ctx, rtr.TaskSpec.Params, params, matrix)
+	if err != nil {
+		errs = multierror.Append(errs, err)
 	}
 	inputs := []v1beta1.TaskResource{}
 	outputs := []v1beta1.TaskResource{}
@@ -211,14 +299,43 @@ func ValidateResolvedTaskResources(ctx context.Context, params []v1beta1.Param,
 		inputs = rtr.TaskSpec.Resources.Inputs
 		outputs = rtr.TaskSpec.Resources.Outputs
 	}
-	if err := validateResources(inputs, rtr.Inputs); err != nil {
-		return fmt.Errorf("invalid input resources for task %s: %w", rtr.TaskName, err)
+	if err := validateResources("inputs", inputs, rtr.Inputs); err != nil {
+		errs = multierror.Append(errs, err)
 	}
-	if err := validateResources(outputs, rtr.Outputs); err != nil {
-		return fmt.Errorf("invalid output resources for task %s: %w", rtr.TaskName, err)
+	if err := validateResources("outputs", outputs, rtr.Outputs); err != nil {
+		errs = multierror.Append(errs, err)
 	}
 
-	return nil
+	finalErr := errs.ErrorOrNil()
+	encoded, encErr := EncodeValidationErrors(AsValidationErrors(finalErr))
+	if encErr != nil {
+		return resolvedDefaults, "", encErr
+	}
+	return resolvedDefaults, encoded, finalErr
+}
+
+// ValidateTaskRun runs ValidateResolvedTaskResources against tr and rtr and sets tr's Succeeded
+// condition accordingly: on failure, Message carries the JSON-encoded ValidationErrors so a
+// single condition surfaces every violation found, instead of only the first. It returns the
+// resolved param defaults on success so the caller can proceed straight to variable
+// substitution.
+func ValidateTaskRun(ctx context.Context, tr *v1beta1.TaskRun, rtr *resources.ResolvedTaskResources) (map[string]v1beta1.ArrayOrString, error) {
+	resolvedDefaults, encoded, err := ValidateResolvedTaskResources(ctx, tr.Spec.Params, nil, rtr)
+	if err != nil {
+		tr.Status.SetCondition(v1beta1.Condition{
+			Type:    v1beta1.ConditionSucceeded,
+			Status:  corev1.ConditionFalse,
+			Reason:  "TaskRunValidationFailed",
+			Message: encoded,
+		})
+		return resolvedDefaults, err
+	}
+	tr.Status.SetCondition(v1beta1.Condition{
+		Type:   v1beta1.ConditionSucceeded,
+		Status: corev1.ConditionUnknown,
+		Reason: "Running",
+	})
+	return resolvedDefaults, nil
 }
 
 func validateTaskSpecRequestResources(taskSpec *v1beta1.TaskSpec) error {
@@ -282,27 +399,60 @@ func validateSidecarOverrides(ts *v1beta1.TaskSpec, trs *v1beta1.TaskRunSpec) er
 	return err
 }
 
-// validateResults checks the emitted results type and object properties against the ones defined in spec.
+// validateTaskRunResults checks the emitted results type and object properties against the ones
+// defined in spec, aggregating every violation rather than stopping at the first.
 func validateTaskRunResults(tr *v1beta1.TaskRun, resolvedTaskSpec *v1beta1.TaskSpec) error {
 	specResults := []v1beta1.TaskResult{}
+	buildType := ""
 	if tr.Spec.TaskSpec != nil {
 		specResults = append(specResults, tr.Spec.TaskSpec.Results...)
+		buildType = tr.Spec.TaskSpec.ResultsBuildType
 	}
 
 	if resolvedTaskSpec != nil {
 		specResults = append(specResults, resolvedTaskSpec.Results...)
+		if buildType == "" {
+			buildType = resolvedTaskSpec.ResultsBuildType
+		}
+	}
+
+	var errs *multierror.Error
+	// A declared BuildType dispatches to its registered ResultSchemaValidator, which enforces
+	// a stricter, provenance-oriented shape on top of the generic checks below.
+	if err := validateResultsBuildType(buildType, tr, specResults); err != nil {
+		errs = multierror.Append(errs, &ValidationError{
+			Kind:    ValidationErrorMismatch,
+			Subject: buildType,
+			Reason:  "results didn't conform to the declared result schema",
+			Detail:  err.Error(),
+		})
 	}
 
 	// When get the results, check if the type of result is the expected one
-	if missmatchedTypes := mismatchedTypesResults(tr, specResults); len(missmatchedTypes) != 0 {
-		return fmt.Errorf("missmatched Types for these results, %v", missmatchedTypes)
+	for name, types := range mismatchedTypesResults(tr, specResults) {
+		errs = multierror.Append(errs, &ValidationError{
+			Kind:    ValidationErrorWrongType,
+			Subject: name,
+			Reason:  "emitted result type doesn't match the type declared in TaskResult",
+			Detail:  fmt.Sprintf("%v", types),
+		})
 	}
 
 	// When get the results, for object value need to check if they have missing keys.
-	if missingKeysObjectNames := missingKeysofObjectResults(tr, specResults); len(missingKeysObjectNames) != 0 {
-		return fmt.Errorf("missing keys for these results which are required in TaskResult's properties %v", missingKeysObjectNames)
+	for name, keys := range missingKeysofObjectResults(tr, specResults) {
+		errs = multierror.Append(errs, &ValidationError{
+			Kind:    ValidationErrorMissingKey,
+			Subject: name,
+			Reason:  "object result is missing keys required by TaskResult's properties",
+			Detail:  fmt.Sprintf("%v", keys),
+		})
 	}
-	return nil
+
+	// When get the results, check any declared Validation expression against the emitted value.
+	for _, verr := range validateResultCELExpressions(specResults, tr.Status.TaskRunResults) {
+		errs = multierror.Append(errs, verr)
+	}
+	return errs.ErrorOrNil()
 }
 
 // mismatchedTypesResults checks and returns all the mismatched types of emitted results against specified results.
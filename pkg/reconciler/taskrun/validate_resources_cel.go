@@ -0,0 +1,248 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package taskrun
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/google/cel-go/cel"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+)
+
+// celProgramCache memoizes compiled CEL programs by expression and `self` type so that
+// repeated evaluations of the same ParamSpec/TaskResult Validation expression (the common
+// case across many TaskRuns of the same Task) don't pay compilation cost on every run.
+var (
+	celProgramCacheMu sync.Mutex
+	celProgramCache   = map[string]cel.Program{}
+)
+
+// celSelfType maps a ParamType to the CEL type used to declare the `self` variable when
+// compiling a Validation expression.
+func celSelfType(pt v1beta1.ParamType) *cel.Type {
+	switch pt {
+	case v1beta1.ParamTypeArray:
+		return cel.ListType(cel.StringType)
+	case v1beta1.ParamTypeObject:
+		return cel.MapType(cel.StringType, cel.StringType)
+	default:
+		return cel.StringType
+	}
+}
+
+// compiledCELProgram returns a cached, compiled CEL program for expr, declaring `self` as
+// selfType and `params`/`results` as string-keyed maps of arbitrary value. It compiles once
+// per distinct (expr, selfType) pair.
+func compiledCELProgram(expr string, selfType *cel.Type) (cel.Program, error) {
+	key := selfType.String() + "|" + expr
+
+	celProgramCacheMu.Lock()
+	defer celProgramCacheMu.Unlock()
+	if prg, ok := celProgramCache[key]; ok {
+		return prg, nil
+	}
+
+	env, err := cel.NewEnv(
+		cel.Variable("self", selfType),
+		cel.Variable("params", cel.MapType(cel.StringType, cel.DynType)),
+		cel.Variable("results", cel.MapType(cel.StringType, cel.DynType)),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create CEL environment: %w", err)
+	}
+	ast, iss := env.Compile(expr)
+	if iss != nil && iss.Err() != nil {
+		return nil, fmt.Errorf("failed to compile CEL expression %q: %w", expr, iss.Err())
+	}
+	prg, err := env.Program(ast)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build CEL program for expression %q: %w", expr, err)
+	}
+	celProgramCache[key] = prg
+	return prg, nil
+}
+
+// evaluateCELPredicate compiles (or reuses a cached compilation of) expr and evaluates it
+// with the given self/params/results bindings. The expression must evaluate to a bool.
+func evaluateCELPredicate(expr string, selfType *cel.Type, self interface{}, params, results map[string]interface{}) (bool, error) {
+	prg, err := compiledCELProgram(expr, selfType)
+	if err != nil {
+		return false, err
+	}
+	out, _, err := prg.Eval(map[string]interface{}{
+		"self":    self,
+		"params":  params,
+		"results": results,
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to evaluate expression %q: %w", expr, err)
+	}
+	result, ok := out.Value().(bool)
+	if !ok {
+		return false, fmt.Errorf("validation expression %q did not evaluate to a bool", expr)
+	}
+	return result, nil
+}
+
+// celValue converts a param/result value to the Go representation handed to CEL for
+// evaluation, matching the shape celSelfType declares for the given type.
+func celValue(pt v1beta1.ParamType, v v1beta1.ArrayOrString) interface{} {
+	switch pt {
+	case v1beta1.ParamTypeArray:
+		return v.ArrayVal
+	case v1beta1.ParamTypeObject:
+		return v.ObjectVal
+	default:
+		return v.StringVal
+	}
+}
+
+// validateParamCELExpressions evaluates each ParamSpec's Validation expression (when set)
+// against the value provided for that param, exposing `self` (the param's own value) and
+// `params` (every directly-bound param, by name) as CEL variables. It returns one
+// ValidationError per param whose value fails, or whose expression can't be evaluated. Matrix
+// params are handled separately: a matrix Param carries an ArrayVal of candidate values rather
+// than the single value its (String-typed, per validateMatrixElementType) ParamSpec expects,
+// so each element is validated on its own instead of evaluating against the Param's (always
+// empty) StringVal.
+func validateParamCELExpressions(paramSpecs []v1beta1.ParamSpec, params []v1beta1.Param, matrix []v1beta1.Param) ValidationErrors {
+	var errs ValidationErrors
+
+	provided := make(map[string]v1beta1.Param, len(params))
+	paramsVar := make(map[string]interface{}, len(params))
+	for _, p := range params {
+		provided[p.Name] = p
+	}
+	for _, spec := range paramSpecs {
+		if p, ok := provided[spec.Name]; ok {
+			paramsVar[spec.Name] = celValue(spec.Type, p.Value)
+		}
+	}
+	matrixProvided := make(map[string]v1beta1.Param, len(matrix))
+	for _, p := range matrix {
+		matrixProvided[p.Name] = p
+	}
+
+	for i, spec := range paramSpecs {
+		if spec.Validation == "" {
+			continue
+		}
+		path := fmt.Sprintf("spec.params[%d]", i)
+		if mp, ok := matrixProvided[spec.Name]; ok {
+			for idx, elem := range mp.Value.ArrayVal {
+				passed, err := evaluateCELPredicate(spec.Validation, celSelfType(v1beta1.ParamTypeString), elem, paramsVar, nil)
+				if err != nil {
+					errs = append(errs, &ValidationError{
+						Kind:    ValidationErrorFailedValidation,
+						Subject: spec.Name,
+						Reason:  "could not evaluate validation expression",
+						Detail:  fmt.Sprintf("matrix element %d: %s: %s", idx, spec.Validation, err),
+						Path:    path,
+					})
+					continue
+				}
+				if !passed {
+					errs = append(errs, &ValidationError{
+						Kind:    ValidationErrorFailedValidation,
+						Subject: spec.Name,
+						Reason:  "matrix element did not satisfy validation expression",
+						Detail:  fmt.Sprintf("element %d, %q does not satisfy %q", idx, elem, spec.Validation),
+						Path:    path,
+					})
+				}
+			}
+			continue
+		}
+		param, ok := provided[spec.Name]
+		if !ok {
+			// Missing params are reported by missingParamsNames; nothing to validate here.
+			continue
+		}
+		self := celValue(spec.Type, param.Value)
+		passed, err := evaluateCELPredicate(spec.Validation, celSelfType(spec.Type), self, paramsVar, nil)
+		if err != nil {
+			errs = append(errs, &ValidationError{
+				Kind:    ValidationErrorFailedValidation,
+				Subject: spec.Name,
+				Reason:  "could not evaluate validation expression",
+				Detail:  fmt.Sprintf("%s: %s", spec.Validation, err),
+				Path:    path,
+			})
+			continue
+		}
+		if !passed {
+			errs = append(errs, &ValidationError{
+				Kind:    ValidationErrorFailedValidation,
+				Subject: spec.Name,
+				Reason:  "value did not satisfy validation expression",
+				Detail:  fmt.Sprintf("%v does not satisfy %q", self, spec.Validation),
+				Path:    path,
+			})
+		}
+	}
+	return errs
+}
+
+// validateResultCELExpressions evaluates each TaskResult's Validation expression (when set)
+// against the value the TaskRun actually emitted for that result, exposing `self` and the
+// full set of emitted results (by name) as `results`.
+func validateResultCELExpressions(specResults []v1beta1.TaskResult, emitted []v1beta1.TaskRunResult) ValidationErrors {
+	var errs ValidationErrors
+
+	provided := make(map[string]v1beta1.TaskRunResult, len(emitted))
+	resultsVar := make(map[string]interface{}, len(emitted))
+	for _, r := range emitted {
+		provided[r.Name] = r
+		resultsVar[r.Name] = celValue(r.Value.Type, r.Value)
+	}
+
+	for i, spec := range specResults {
+		if spec.Validation == "" {
+			continue
+		}
+		result, ok := provided[spec.Name]
+		if !ok {
+			continue
+		}
+		path := fmt.Sprintf("spec.results[%d]", i)
+		self := celValue(spec.Type, result.Value)
+		passed, err := evaluateCELPredicate(spec.Validation, celSelfType(spec.Type), self, nil, resultsVar)
+		if err != nil {
+			errs = append(errs, &ValidationError{
+				Kind:    ValidationErrorFailedValidation,
+				Subject: spec.Name,
+				Reason:  "could not evaluate validation expression",
+				Detail:  fmt.Sprintf("%s: %s", spec.Validation, err),
+				Path:    path,
+			})
+			continue
+		}
+		if !passed {
+			errs = append(errs, &ValidationError{
+				Kind:    ValidationErrorFailedValidation,
+				Subject: spec.Name,
+				Reason:  "value did not satisfy validation expression",
+				Detail:  fmt.Sprintf("%v does not satisfy %q", self, spec.Validation),
+				Path:    path,
+			})
+		}
+	}
+	return errs
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package taskrun
+
+import (
+	"testing"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+)
+
+func TestValidateParamCELExpressionsPass(t *testing.T) {
+	specs := []v1beta1.ParamSpec{
+		{Name: "count", Type: v1beta1.ParamTypeString, Validation: "int(self) > 0"},
+	}
+	params := []v1beta1.Param{
+		{Name: "count", Value: v1beta1.ArrayOrString{Type: v1beta1.ParamTypeString, StringVal: "3"}},
+	}
+	if errs := validateParamCELExpressions(specs, params, nil); len(errs) != 0 {
+		t.Errorf("unexpected validation errors: %v", errs)
+	}
+}
+
+func TestValidateParamCELExpressionsFail(t *testing.T) {
+	specs := []v1beta1.ParamSpec{
+		{Name: "count", Type: v1beta1.ParamTypeString, Validation: "int(self) > 0"},
+	}
+	params := []v1beta1.Param{
+		{Name: "count", Value: v1beta1.ArrayOrString{Type: v1beta1.ParamTypeString, StringVal: "-1"}},
+	}
+	errs := validateParamCELExpressions(specs, params, nil)
+	if len(errs) != 1 || errs[0].Kind != ValidationErrorFailedValidation {
+		t.Errorf("validateParamCELExpressions() = %v, want one FailedValidation error", errs)
+	}
+}
+
+func TestValidateParamCELExpressionsReferencesOtherParam(t *testing.T) {
+	specs := []v1beta1.ParamSpec{
+		{Name: "min", Type: v1beta1.ParamTypeString},
+		{Name: "count", Type: v1beta1.ParamTypeString, Validation: "int(self) >= int(params['min'])"},
+	}
+	params := []v1beta1.Param{
+		{Name: "min", Value: v1beta1.ArrayOrString{Type: v1beta1.ParamTypeString, StringVal: "5"}},
+		{Name: "count", Value: v1beta1.ArrayOrString{Type: v1beta1.ParamTypeString, StringVal: "3"}},
+	}
+	errs := validateParamCELExpressions(specs, params, nil)
+	if len(errs) != 1 {
+		t.Errorf("validateParamCELExpressions() = %v, want one error since count < min", errs)
+	}
+}
+
+func TestValidateParamCELExpressionsMalformed(t *testing.T) {
+	specs := []v1beta1.ParamSpec{
+		{Name: "count", Type: v1beta1.ParamTypeString, Validation: "this is not valid CEL("},
+	}
+	params := []v1beta1.Param{
+		{Name: "count", Value: v1beta1.ArrayOrString{Type: v1beta1.ParamTypeString, StringVal: "3"}},
+	}
+	errs := validateParamCELExpressions(specs, params, nil)
+	if len(errs) != 1 || errs[0].Kind != ValidationErrorFailedValidation {
+		t.Errorf("validateParamCELExpressions() = %v, want one FailedValidation error for a malformed expression", errs)
+	}
+}
+
+func TestValidateParamCELExpressionsMatrixValidatesEachElement(t *testing.T) {
+	specs := []v1beta1.ParamSpec{
+		{Name: "platform", Type: v1beta1.ParamTypeString, Validation: "self in ['linux', 'darwin']"},
+	}
+	matrix := []v1beta1.Param{
+		{Name: "platform", Value: v1beta1.ArrayOrString{Type: v1beta1.ParamTypeArray, ArrayVal: []string{"linux", "darwin"}}},
+	}
+	if errs := validateParamCELExpressions(specs, nil, matrix); len(errs) != 0 {
+		t.Errorf("unexpected validation errors for valid matrix elements: %v", errs)
+	}
+
+	matrix[0].Value.ArrayVal = []string{"linux", "windows"}
+	errs := validateParamCELExpressions(specs, nil, matrix)
+	if len(errs) != 1 {
+		t.Errorf("validateParamCELExpressions() = %v, want exactly one error for the invalid element", errs)
+	}
+}
+
+func TestValidateResultCELExpressions(t *testing.T) {
+	specs := []v1beta1.TaskResult{
+		{Name: "digest", Type: v1beta1.ParamTypeString, Validation: "self.startsWith('sha256:')"},
+	}
+	emitted := []v1beta1.TaskRunResult{
+		{Name: "digest", Value: v1beta1.ArrayOrString{Type: v1beta1.ParamTypeString, StringVal: "sha256:abc"}},
+	}
+	if errs := validateResultCELExpressions(specs, emitted); len(errs) != 0 {
+		t.Errorf("unexpected validation errors: %v", errs)
+	}
+
+	emitted[0].Value.StringVal = "abc"
+	if errs := validateResultCELExpressions(specs, emitted); len(errs) != 1 {
+		t.Errorf("validateResultCELExpressions() = %v, want one error for a non-conforming digest", errs)
+	}
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package taskrun
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/go-multierror"
+)
+
+// ValidationErrorKind classifies a ValidationError so programmatic consumers can branch on
+// failure type without parsing prose out of Reason.
+type ValidationErrorKind string
+
+const (
+	ValidationErrorMissing          ValidationErrorKind = "Missing"
+	ValidationErrorExtra            ValidationErrorKind = "Extra"
+	ValidationErrorWrongType        ValidationErrorKind = "WrongType"
+	ValidationErrorMissingKey       ValidationErrorKind = "MissingKey"
+	ValidationErrorFailedValidation ValidationErrorKind = "FailedValidation"
+	ValidationErrorMismatch         ValidationErrorKind = "Mismatch"
+	ValidationErrorCycle            ValidationErrorKind = "Cycle"
+)
+
+// ValidationError is a single, structured validation violation. Subject names the
+// param/result/resource the violation concerns, Reason is a short human-readable summary and
+// Detail carries any supporting context (an offending value, a failed expression, a list of
+// missing keys). Path locates the violation within the request (e.g. "spec.params[2]") for
+// callers that want to point a user at the offending field; it's left empty when a violation
+// isn't tied to one field in particular.
+type ValidationError struct {
+	Kind    ValidationErrorKind `json:"kind"`
+	Subject string              `json:"subject"`
+	Reason  string              `json:"reason"`
+	Detail  string              `json:"detail,omitempty"`
+	Path    string              `json:"path,omitempty"`
+}
+
+var _ error = (*ValidationError)(nil)
+
+func (e *ValidationError) Error() string {
+	if e.Detail != "" {
+		return fmt.Sprintf("%s %q: %s (%s)", e.Kind, e.Subject, e.Reason, e.Detail)
+	}
+	return fmt.Sprintf("%s %q: %s", e.Kind, e.Subject, e.Reason)
+}
+
+// ValidationErrors is the JSON-encodable collection of every violation found during a single
+// validation pass, suitable for embedding verbatim in a Condition's Message so that UIs and
+// CLIs can render per-field diagnostics instead of scraping prose.
+type ValidationErrors []*ValidationError
+
+// EncodeValidationErrors renders errs as a stable JSON document. Callers typically obtain errs
+// via AsValidationErrors on whatever validateParams/validateResources/etc. returned.
+func EncodeValidationErrors(errs ValidationErrors) (string, error) {
+	b, err := json.Marshal(errs)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode validation errors: %w", err)
+	}
+	return string(b), nil
+}
+
+// AsValidationErrors flattens err into a ValidationErrors slice for encoding. err is expected
+// to be nil, a *ValidationError, or a *multierror.Error whose members are themselves
+// *ValidationError or *multierror.Error (as produced by validateResources, validateParams,
+// ValidateResolvedTaskResources and validateTaskRunResults). Anything else is wrapped so a
+// caller never silently drops an error that predates the ValidationError model.
+func AsValidationErrors(err error) ValidationErrors {
+	if err == nil {
+		return nil
+	}
+	if merr, ok := err.(*multierror.Error); ok {
+		var out ValidationErrors
+		for _, e := range merr.Errors {
+			out = append(out, AsValidationErrors(e)...)
+		}
+		return out
+	}
+	if verr, ok := err.(*ValidationError); ok {
+		return ValidationErrors{verr}
+	}
+	return ValidationErrors{{Kind: "Unknown", Reason: err.Error()}}
+}
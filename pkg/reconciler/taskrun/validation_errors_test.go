@@ -0,0 +1,121 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package taskrun
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-multierror"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	resourcev1alpha1 "github.com/tektoncd/pipeline/pkg/apis/resource/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/reconciler/taskrun/resources"
+)
+
+func TestValidationErrorError(t *testing.T) {
+	withDetail := &ValidationError{Kind: ValidationErrorMissing, Subject: "count", Reason: "no value provided", Detail: "and no default"}
+	if got := withDetail.Error(); !strings.Contains(got, "count") || !strings.Contains(got, "and no default") {
+		t.Errorf("Error() = %q, want it to mention the subject and the detail", got)
+	}
+
+	noDetail := &ValidationError{Kind: ValidationErrorExtra, Subject: "extra", Reason: "param was provided but is not declared by the Task"}
+	if got := noDetail.Error(); strings.Contains(got, "()") {
+		t.Errorf("Error() = %q, should not leave an empty trailing detail parenthetical", got)
+	}
+}
+
+func TestEncodeValidationErrors(t *testing.T) {
+	errs := ValidationErrors{
+		{Kind: ValidationErrorMissing, Subject: "count", Reason: "no value provided", Path: "spec.params[0]"},
+	}
+	encoded, err := EncodeValidationErrors(errs)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, want := range []string{`"kind":"Missing"`, `"subject":"count"`, `"path":"spec.params[0]"`} {
+		if !strings.Contains(encoded, want) {
+			t.Errorf("EncodeValidationErrors() = %q, want it to contain %q", encoded, want)
+		}
+	}
+}
+
+func TestAsValidationErrorsFlattensMultierror(t *testing.T) {
+	inner := multierror.Append(nil,
+		&ValidationError{Kind: ValidationErrorMissing, Subject: "a"},
+		&ValidationError{Kind: ValidationErrorExtra, Subject: "b"},
+	)
+	outer := multierror.Append(nil, inner, &ValidationError{Kind: ValidationErrorWrongType, Subject: "c"})
+
+	errs := AsValidationErrors(outer)
+	if len(errs) != 3 {
+		t.Fatalf("AsValidationErrors() = %v, want 3 flattened errors", errs)
+	}
+}
+
+func TestAsValidationErrorsNil(t *testing.T) {
+	if errs := AsValidationErrors(nil); errs != nil {
+		t.Errorf("AsValidationErrors(nil) = %v, want nil", errs)
+	}
+}
+
+func TestValidateResourcesTagsDirection(t *testing.T) {
+	required := []v1beta1.TaskResource{{Name: "repo", Type: resourcev1alpha1.PipelineResourceType("git")}}
+
+	err := validateResources("inputs", required, nil)
+	if err == nil {
+		t.Fatal("expected an error for a missing required resource")
+	}
+	errs := AsValidationErrors(err)
+	if len(errs) != 1 || errs[0].Subject != "resources.inputs" {
+		t.Errorf("validateResources(\"inputs\", ...) = %v, want a single resources.inputs error", errs)
+	}
+
+	err = validateResources("outputs", required, nil)
+	errs = AsValidationErrors(err)
+	if len(errs) != 1 || errs[0].Subject != "resources.outputs" {
+		t.Errorf("validateResources(\"outputs\", ...) = %v, want a single resources.outputs error", errs)
+	}
+}
+
+func TestValidateResolvedTaskResourcesAggregatesBothDirections(t *testing.T) {
+	rtr := &resources.ResolvedTaskResources{
+		TaskSpec: &v1beta1.TaskSpec{
+			Resources: &v1beta1.TaskResources{
+				Inputs:  []v1beta1.TaskResource{{Name: "repo", Type: resourcev1alpha1.PipelineResourceType("git")}},
+				Outputs: []v1beta1.TaskResource{{Name: "image", Type: resourcev1alpha1.PipelineResourceType("image")}},
+			},
+		},
+	}
+
+	_, encoded, err := ValidateResolvedTaskResources(context.Background(), nil, nil, rtr)
+	if err == nil {
+		t.Fatal("expected an error for two missing resources")
+	}
+	errs := AsValidationErrors(err)
+	subjects := map[string]bool{}
+	for _, verr := range errs {
+		subjects[verr.Subject] = true
+	}
+	if !subjects["resources.inputs"] || !subjects["resources.outputs"] {
+		t.Errorf("ValidateResolvedTaskResources() errors = %v, want distinguishable resources.inputs and resources.outputs entries", errs)
+	}
+	if !strings.Contains(encoded, "resources.inputs") || !strings.Contains(encoded, "resources.outputs") {
+		t.Errorf("encoded = %q, want the JSON-encoded errors to distinguish inputs from outputs", encoded)
+	}
+}